@@ -4,7 +4,10 @@ import (
 	"log"
 	"module"
 	"net/http"
+	"os"
+	"os/signal"
 	"strconv"
+	"syscall"
 
 	"github.com/VividCortex/godaemon"
 )
@@ -21,6 +24,8 @@ func startServer(port int) {
 	http.Handle("/", http.FileServer(FS(false)))
 	// 客户端处理操作
 	http.HandleFunc("/action", module.ReqHandler)
+	// 服务端推送的事件
+	http.HandleFunc("/events", module.EventHandler)
 	err := http.ListenAndServe(addr, nil)
 	if err != nil {
 		log.Fatal("ListenAndServe:", err)
@@ -30,5 +35,13 @@ func startServer(port int) {
 func main() {
 	godaemon.MakeDaemon(&godaemon.DaemonAttr{})
 	module.Init()
+	// 退出时先关闭托管的aria2c
+	go func() {
+		c := make(chan os.Signal, 1)
+		signal.Notify(c, os.Interrupt, syscall.SIGTERM)
+		<-c
+		module.Shutdown()
+		os.Exit(0)
+	}()
 	startServer(port)
 }